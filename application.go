@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"os"
+	"os/signal"
 	"sync"
-	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -38,12 +40,18 @@ type (
 		// InitializationTimeout limits the time to initialize resources.
 		// If the resources are not initialized within the allotted time, the application will not be launched
 		InitializationTimeout time.Duration
+		// ShutdownSignals lists the OS signals that trigger a graceful shutdown through Halt.
+		// Receiving any of them a second time forces an immediate Shutdown instead of waiting
+		// for TerminationTimeout. Defaults to os.Interrupt and syscall.SIGTERM when left nil.
+		ShutdownSignals []os.Signal
 
 		appState int32
 		err error
 		mux sync.Mutex
 		halt chan struct{}
 		done chan struct{}
+		force chan struct{}
+		forceOnce sync.Once
 
 	}
 )
@@ -55,54 +63,236 @@ const (
 	appStateShutdown
 )
 
+// Run is equivalent to RunContext(context.Background()).
 func (a *Application) Run() error {
+	return a.RunContext(context.Background())
+}
+
+// RunContext runs the application with a caller-supplied context: cancelling ctx has the same
+// effect as calling Halt. Run orchestrates the whole lifecycle: it initializes Resources,
+// watches them in the background, runs MainFunc, waits for it to finish (within
+// TerminationTimeout once Halt has fired, or immediately on Shutdown), and then stops and
+// releases Resources before returning.
+func (a *Application) RunContext(ctx context.Context) error {
 	if a.MainFunc == nil {
 		// if this func is not set, then nothing to do
 		return ErrMainOmitted
 	}
 
-	if a.checkState(appStateInit, appStateRunning) {
+	a.mux.Lock()
+	if a.appState != appStateInit {
+		a.mux.Unlock()
 		// can't enter here twice
-		if err := a.init(); err != nil {
-			a.err = err
-			a.appState = appStateShutdown
-			// resources initialisation isn't done
-			return err
+		return ErrWrongState
+	}
+	a.halt = make(chan struct{})
+	a.done = make(chan struct{})
+	a.force = make(chan struct{})
+	a.appState = appStateRunning
+	a.mux.Unlock()
+	defer close(a.done)
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			a.Halt()
+		case <-a.halt:
+		}
+	}()
+
+	if err := a.init(ctx); err != nil {
+		a.setErr(err)
+		a.checkState(appStateRunning, appStateShutdown)
+		// resources initialisation isn't done
+		return err
+	}
+
+	a.notifySignals()
+
+	// by means watchDone we synchronice resources lifecycle with
+	// application lifecycle
+	watchDone := make(chan struct{})
+	if a.Resources != nil {
+		go func() {
+			defer close(watchDone)
+			a.setErr(a.Resources.Watch(ctx))
+			a.Halt()
+		}()
+	} else {
+		close(watchDone)
+	}
+
+	mainDone := make(chan error, 1)
+	go func() {
+		mainDone <- a.MainFunc(ctx, a.halt)
+	}()
+
+	select {
+	case err := <-mainDone:
+		a.setErr(err)
+	case <-a.halt:
+		// a zero TerminationTimeout means "no bound", matching PingTimeout in services.go,
+		// so leave the timeout arm disabled rather than firing immediately.
+		var timeout <-chan time.Time
+		if a.TerminationTimeout > 0 {
+			timer := time.NewTimer(a.TerminationTimeout)
+			defer timer.Stop()
+			timeout = timer.C
 		}
 
-		// by means servicesRunning we synchronice resources lifecycle with
-		// application lifecycle
-		var servicesRunning = make(chan struct{})
-		if a.Resources != nil {
-			go func ()  {
-				defer close(servicesRunning) //this signal about Watch stopped
-				defer a.shutdown
-			}
+		select {
+		case err := <-mainDone:
+			a.setErr(err)
+		case <-a.force:
+		case <-timeout:
+			a.setErr(ErrTermTimeout)
 		}
 	}
-}
 
-func (a *Application) init() error  {
+	a.checkState(appStateRunning, appStateShutdown)
+	a.checkState(appStateHalt, appStateShutdown)
+
 	if a.Resources != nil {
-		ctx, cancel := context.WithTimeout(context.TODO(), a.InitializationTimeout)
+		a.Resources.Stop()
+	}
+	<-watchDone
+
+	if a.Resources != nil {
+		a.Resources.Release()
+	}
+
+	return a.Err()
+}
+
+// setErr records err as the result of Run, unless Run already has one.
+func (a *Application) setErr(err error) {
+	if err == nil {
+		return
+	}
+
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.err == nil {
+		a.err = err
+	}
+}
+
+// notifySignals subscribes to ShutdownSignals (or the SIGINT/SIGTERM default) and drives the
+// application through Halt on the first occurrence and Shutdown on the second, so callers no
+// longer need to wire up signal.Notify and the halt channel themselves.
+func (a *Application) notifySignals() {
+	signals := a.ShutdownSignals
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, signals...)
+
+	go func() {
+		defer signal.Stop(sigCh)
+
+		select {
+		case <-sigCh:
+			a.Halt()
+		case <-a.halt:
+			return
+		}
+
+		select {
+		case <-sigCh:
+			a.Shutdown()
+		case <-a.done:
+		}
+	}()
+}
+
+// Done returns a channel that is closed once Run has returned, allowing external code to
+// observe termination without blocking on Run itself.
+func (a *Application) Done() <-chan struct{} {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return a.done
+}
+
+// Err returns the error Run terminated with, if any. It is only meaningful once Done is closed.
+func (a *Application) Err() error {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return a.err
+}
+
+// Running reports whether Run has started and has not been halted yet. It is already true
+// while Resources.Init is in progress.
+func (a *Application) Running() bool {
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	return a.appState == appStateRunning
+}
+
+// init runs Resources.Init bounded by InitializationTimeout, derived from ctx so external
+// cancellation is honored. A zero InitializationTimeout means "unbounded", matching
+// TerminationTimeout/PingTimeout elsewhere in this package.
+func (a *Application) init(ctx context.Context) error {
+	if a.Resources == nil {
+		return nil
+	}
+
+	if a.InitializationTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.InitializationTimeout)
 		defer cancel()
-		return a.Resources.Init(ctx)
 	}
-	return nil
+
+	return a.Resources.Init(ctx)
 }
 
+// checkState atomically moves appState from old to new, guarded by the same mutex that
+// protects every other read/write of appState and err, and reports whether it did.
 func (a *Application) checkState(old, new int32) bool {
-	return atomic.CompareAndSwapInt32(&a.appState, old, new)
+	a.mux.Lock()
+	defer a.mux.Unlock()
+	if a.appState != old {
+		return false
+	}
+	a.appState = new
+	return true
 }
 
 // Halt signals the application to terminate the current computational processes and prepare to stop the application
 func (a *Application) Halt() {
-	if a.checkState(appStateRunning, appStateHalt) {
-		close(a.halt)
+	// halt is read under the same lock that RunContext uses to allocate it and flip appState
+	// to Running, so a Halt racing with the very start of Run can never observe appStateRunning
+	// before halt exists.
+	a.mux.Lock()
+	halt := a.halt
+	ok := a.appState == appStateRunning
+	if ok {
+		a.appState = appStateHalt
+	}
+	a.mux.Unlock()
+
+	if ok {
+		close(halt)
 	}
 }
 
-// Shutdown stops the application immediately. At this point all calculations should be completed
+// Shutdown stops the application immediately, without waiting for TerminationTimeout. At this
+// point all calculations should be completed.
 func (a *Application) Shutdown()  {
 	a.Halt()
+
+	a.mux.Lock()
+	force := a.force
+	a.mux.Unlock()
+
+	if force == nil {
+		return
+	}
+	a.forceOnce.Do(func() {
+		close(force)
+	})
 }
\ No newline at end of file
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRunContextZeroTerminationTimeoutWaitsForMainFunc(t *testing.T) {
+	app := &Application{
+		MainFunc: func(ctx context.Context, halt <-chan struct{}) error {
+			<-halt
+			time.Sleep(50 * time.Millisecond)
+			return nil
+		},
+	}
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		app.Halt()
+	}()
+
+	err := app.Run()
+	if err != nil {
+		t.Fatalf("Run() = %v, want nil: a zero TerminationTimeout must not cut off a MainFunc that returns shortly after Halt", err)
+	}
+}
+
+func TestRunContextTerminationTimeoutExpires(t *testing.T) {
+	app := &Application{
+		TerminationTimeout: 10 * time.Millisecond,
+		MainFunc: func(ctx context.Context, halt <-chan struct{}) error {
+			<-halt
+			<-make(chan struct{}) // never returns on its own
+			return nil
+		},
+	}
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		app.Halt()
+	}()
+
+	if err := app.Run(); !errors.Is(err, ErrTermTimeout) {
+		t.Fatalf("Run() = %v, want ErrTermTimeout", err)
+	}
+}
+
+func TestApplicationShutdownForcesImmediateReturn(t *testing.T) {
+	app := &Application{
+		TerminationTimeout: time.Hour,
+		MainFunc: func(ctx context.Context, halt <-chan struct{}) error {
+			<-halt
+			<-make(chan struct{}) // never returns on its own
+			return nil
+		},
+	}
+
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		app.Run()
+	}()
+
+	for !app.Running() {
+		time.Sleep(time.Millisecond)
+	}
+	app.Shutdown()
+
+	select {
+	case <-runDone:
+	case <-time.After(time.Second):
+		t.Fatal("Run() did not return after Shutdown")
+	}
+}
+
+func TestApplicationHaltIsIdempotent(t *testing.T) {
+	app := &Application{
+		MainFunc: func(ctx context.Context, halt <-chan struct{}) error {
+			<-halt
+			return nil
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		app.Run()
+	}()
+
+	time.Sleep(5 * time.Millisecond)
+	for i := 0; i < 5; i++ {
+		app.Halt()
+	}
+	<-done
+}
+
+func TestApplicationHaltBeforeRunDoesNotPanic(t *testing.T) {
+	app := &Application{}
+	app.Halt()
+	app.Shutdown()
+}
+
+// slowInitResources is a Resources fake whose Watch only returns once Stop is called (or ctx
+// is cancelled), matching the documented Resources contract.
+type slowInitResources struct {
+	delay time.Duration
+	stop  chan struct{}
+}
+
+func newSlowInitResources(delay time.Duration) *slowInitResources {
+	return &slowInitResources{delay: delay, stop: make(chan struct{})}
+}
+
+func (r *slowInitResources) Init(ctx context.Context) error {
+	select {
+	case <-time.After(r.delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+func (r *slowInitResources) Watch(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-r.stop:
+		return nil
+	}
+}
+func (r *slowInitResources) Stop()    { close(r.stop) }
+func (r *slowInitResources) Release() {}
+
+func TestRunContextCancelHonoredDuringInit(t *testing.T) {
+	app := &Application{
+		Resources: newSlowInitResources(500 * time.Millisecond),
+		MainFunc:  func(ctx context.Context, halt <-chan struct{}) error { return nil },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	err := app.RunContext(ctx)
+	if elapsed := time.Since(start); elapsed > 200*time.Millisecond {
+		t.Fatalf("RunContext took %v, want it to return shortly after ctx was cancelled during Init", elapsed)
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("RunContext() = %v, want context.Canceled", err)
+	}
+}
+
+func TestRunContextZeroInitializationTimeoutDoesNotExpireImmediately(t *testing.T) {
+	app := &Application{
+		Resources: Sequentially(newSlowInitResources(0), newSlowInitResources(0)),
+		MainFunc:  func(ctx context.Context, halt <-chan struct{}) error { return nil },
+	}
+
+	if err := app.Run(); err != nil {
+		t.Fatalf("Run() = %v, want nil: a zero InitializationTimeout must not expire instantly", err)
+	}
+}
+
+func TestApplicationStateAccessUnderConcurrency(t *testing.T) {
+	app := &Application{
+		MainFunc: func(ctx context.Context, halt <-chan struct{}) error {
+			<-halt
+			return nil
+		},
+	}
+
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		app.Run()
+	}()
+
+	for !app.Running() {
+		time.Sleep(time.Millisecond)
+	}
+
+	readersDone := make(chan struct{})
+	go func() {
+		defer close(readersDone)
+		for i := 0; i < 100; i++ {
+			app.Running()
+			app.Err()
+		}
+	}()
+
+	// a concurrent Run() call while the first is already running must be rejected, not race.
+	if err := app.Run(); err != ErrWrongState {
+		t.Fatalf("second Run() = %v, want ErrWrongState", err)
+	}
+
+	<-readersDone
+	app.Halt()
+	<-runDone
+}
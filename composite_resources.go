@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type compositeMode int
+
+const (
+	compositeSequential compositeMode = iota
+	compositeConcurrent
+)
+
+// CompositeResources implements Resources by composing a tree of child Resources, letting
+// callers express real dependency graphs (e.g. a DB must be up before the HTTP server, while
+// a cache and a message bus may come up in parallel) instead of a single flat list.
+//
+// Build a tree with Sequentially and Concurrently; the two can be nested arbitrarily, e.g.
+// Sequentially(db, Concurrently(cache, mq), httpServer).
+type CompositeResources struct {
+	children []Resources
+	mode     compositeMode
+
+	// InitTimeout, when non-zero, bounds Init for this node independently of any deadline
+	// already present on the context passed in by the parent.
+	InitTimeout time.Duration
+}
+
+// Sequentially returns a Resources node whose children are initialized one after another in
+// the given order; Release runs them in reverse order. Watch still runs them in parallel.
+func Sequentially(r ...Resources) Resources {
+	return &CompositeResources{children: r, mode: compositeSequential}
+}
+
+// Concurrently returns a Resources node whose children are initialized and watched in
+// parallel; Release still runs them in reverse order.
+func Concurrently(r ...Resources) Resources {
+	return &CompositeResources{children: r, mode: compositeConcurrent}
+}
+
+func (c *CompositeResources) Init(ctx context.Context) error {
+	if c.InitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.InitTimeout)
+		defer cancel()
+	}
+
+	if c.mode == compositeConcurrent {
+		return c.initConcurrently(ctx)
+	}
+	return c.initSequentially(ctx)
+}
+
+func (c *CompositeResources) initSequentially(ctx context.Context) error {
+	for _, r := range c.children {
+		if err := r.Init(ctx); err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CompositeResources) initConcurrently(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	wg.Add(len(c.children))
+	for _, r := range c.children {
+		r := r
+		go func() {
+			defer wg.Done()
+			if err := r.Init(ctx); err != nil {
+				once.Do(func() {
+					firstErr = err
+					// abort siblings still initializing
+					cancel()
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// Watch fans out to every child concurrently, regardless of the node's mode, and returns as
+// soon as the first one returns, with that child's result (nil if it exited cleanly),
+// cancelling the rest.
+func (c *CompositeResources) Watch(ctx context.Context) error {
+	if len(c.children) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errCh := make(chan error, len(c.children))
+	for _, r := range c.children {
+		r := r
+		go func() {
+			errCh <- r.Watch(ctx)
+		}()
+	}
+
+	err := <-errCh
+
+	// the cancellation above asks siblings to wind down; drain them in the background so
+	// their goroutines don't leak once this call returns.
+	go func() {
+		for i := 1; i < len(c.children); i++ {
+			<-errCh
+		}
+	}()
+
+	return err
+}
+
+func (c *CompositeResources) Stop() {
+	for _, r := range c.children {
+		r.Stop()
+	}
+}
+
+func (c *CompositeResources) Release() {
+	for i := len(c.children) - 1; i >= 0; i-- {
+		c.children[i].Release()
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordingResources struct {
+	name string
+	mux  *sync.Mutex
+	log  *[]string
+}
+
+func (r recordingResources) Init(context.Context) error {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	*r.log = append(*r.log, r.name)
+	return nil
+}
+func (r recordingResources) Watch(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+func (r recordingResources) Stop()                           {}
+func (r recordingResources) Release() {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	*r.log = append(*r.log, "release:"+r.name)
+}
+
+func TestSequentiallyInitOrderAndReverseRelease(t *testing.T) {
+	var mux sync.Mutex
+	var log []string
+
+	a := recordingResources{name: "a", mux: &mux, log: &log}
+	b := recordingResources{name: "b", mux: &mux, log: &log}
+
+	r := Sequentially(a, b)
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("Init() = %v, want nil", err)
+	}
+	r.Release()
+
+	want := []string{"a", "b", "release:b", "release:a"}
+	if len(log) != len(want) {
+		t.Fatalf("log = %v, want %v", log, want)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Fatalf("log = %v, want %v", log, want)
+		}
+	}
+}
+
+func TestConcurrentlyInitRunsAllChildren(t *testing.T) {
+	var mux sync.Mutex
+	var log []string
+
+	a := recordingResources{name: "a", mux: &mux, log: &log}
+	b := recordingResources{name: "b", mux: &mux, log: &log}
+
+	r := Concurrently(a, b)
+	if err := r.Init(context.Background()); err != nil {
+		t.Fatalf("Init() = %v, want nil", err)
+	}
+
+	if len(log) != 2 {
+		t.Fatalf("log = %v, want both children to have initialized", log)
+	}
+}
+
+func TestCompositeResourcesWatchEmptyChildrenReturnsImmediately(t *testing.T) {
+	done := make(chan error, 1)
+	go func() {
+		done <- Sequentially().Watch(context.Background())
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Watch() = %v, want nil", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch() on an empty composite blocked forever")
+	}
+}
@@ -6,6 +6,9 @@ const (
 	ErrWrongState appError = "wrong application state"
 	ErrMainOmitted appError = "main function is omitted"
 	ErrTermTimeout appError = "termination timeout"
+	// ErrServiceTimeout is wrapped by the error WithTimeout returns when a decorated Service
+	// call does not complete within its configured timeout.
+	ErrServiceTimeout appError = "service call timed out"
 )
 
 func (e appError) Error() string {
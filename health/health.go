@@ -0,0 +1,108 @@
+// Package health mounts Kubernetes-style liveness/readiness/health endpoints backed by a
+// service.Application and its service.ServiceKeeper.
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	service "github.com/alekslesik/goservise"
+)
+
+type handler struct {
+	app    *service.Application
+	keeper *service.ServiceKeeper
+}
+
+// NewHandler returns an http.Handler serving /livez, /readyz and /healthz for app and keeper,
+// so it can be mounted on an existing mux. keeper may be nil if the application keeps no
+// services; readiness then only reflects the application's own running state.
+func NewHandler(app *service.Application, keeper *service.ServiceKeeper) http.Handler {
+	h := &handler{app: app, keeper: keeper}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livez", h.livez)
+	mux.HandleFunc("/readyz", h.readyz)
+	mux.HandleFunc("/healthz", h.healthz)
+	return mux
+}
+
+// Serve mounts NewHandler(app, keeper) on its own listener at addr, the way a controller-runtime
+// manager runs a dedicated probe server.
+func Serve(addr string, app *service.Application, keeper *service.ServiceKeeper) error {
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: NewHandler(app, keeper),
+	}
+	return srv.ListenAndServe()
+}
+
+func (h *handler) livez(w http.ResponseWriter, r *http.Request) {
+	if !h.app.Running() {
+		http.Error(w, "not running", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *handler) readyz(w http.ResponseWriter, r *http.Request) {
+	if !h.app.Running() {
+		http.Error(w, "not running", http.StatusServiceUnavailable)
+		return
+	}
+
+	if h.keeper == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !h.keeper.Initialized() {
+		http.Error(w, "resources not initialized", http.StatusServiceUnavailable)
+		return
+	}
+
+	for i := range h.keeper.Services {
+		lastPing, err := h.keeper.LastPing(i)
+		if lastPing.IsZero() || err != nil {
+			http.Error(w, "service not ready", http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+type serviceStatus struct {
+	Service             string    `json:"service"`
+	LastPing            time.Time `json:"last_ping"`
+	LastError           string    `json:"last_error,omitempty"`
+	ConsecutiveFailures int       `json:"consecutive_failures"`
+}
+
+type healthReport struct {
+	Services []serviceStatus `json:"services"`
+}
+
+func (h *handler) healthz(w http.ResponseWriter, r *http.Request) {
+	report := healthReport{}
+
+	if h.keeper != nil {
+		for i, svc := range h.keeper.Services {
+			lastPing, err := h.keeper.LastPing(i)
+			status := serviceStatus{
+				Service:             fmt.Sprintf("%T", svc),
+				LastPing:            lastPing,
+				ConsecutiveFailures: h.keeper.Failures(i),
+			}
+			if err != nil {
+				status.LastError = err.Error()
+			}
+			report.Services = append(report.Services, status)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+}
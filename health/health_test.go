@@ -0,0 +1,117 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	service "github.com/alekslesik/goservise"
+)
+
+// okService is a minimal service.Service fake that always succeeds.
+type okService struct{}
+
+func (okService) Init(context.Context) error { return nil }
+func (okService) Ping(context.Context) error { return nil }
+func (okService) Close() error               { return nil }
+
+func get(t *testing.T, h http.Handler, path string) *http.Response {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec.Result()
+}
+
+func TestLivezAndReadyzReturn503BeforeRunning(t *testing.T) {
+	app := &service.Application{MainFunc: func(context.Context, <-chan struct{}) error { return nil }}
+	h := NewHandler(app, nil)
+
+	for _, path := range []string{"/livez", "/readyz"} {
+		if resp := get(t, h, path); resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("%s before Run = %d, want %d", path, resp.StatusCode, http.StatusServiceUnavailable)
+		}
+	}
+}
+
+func TestReadyzReturns200OncePinged(t *testing.T) {
+	app := &service.Application{
+		MainFunc: func(ctx context.Context, halt <-chan struct{}) error {
+			<-halt
+			return nil
+		},
+	}
+	runDone := make(chan struct{})
+	go func() {
+		defer close(runDone)
+		app.Run()
+	}()
+	for !app.Running() {
+		time.Sleep(time.Millisecond)
+	}
+	defer func() {
+		app.Halt()
+		<-runDone
+	}()
+
+	keeper := &service.ServiceKeeper{Services: []service.Service{okService{}}, PingPeriod: time.Millisecond}
+	if err := keeper.Init(context.Background()); err != nil {
+		t.Fatalf("keeper.Init() = %v, want nil", err)
+	}
+	watchDone := make(chan struct{})
+	go func() {
+		defer close(watchDone)
+		keeper.Watch(context.Background())
+	}()
+	defer func() {
+		keeper.Stop()
+		<-watchDone
+	}()
+
+	h := NewHandler(app, keeper)
+
+	if resp := get(t, h, "/readyz"); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("/readyz before any ping = %d, want %d", resp.StatusCode, http.StatusServiceUnavailable)
+	}
+
+	for i, err := keeper.LastPing(0); i.IsZero(); i, err = keeper.LastPing(0) {
+		if err != nil {
+			t.Fatalf("LastPing(0) err = %v, want nil", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if resp := get(t, h, "/readyz"); resp.StatusCode != http.StatusOK {
+		t.Fatalf("/readyz after a successful ping = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestHealthzReportsPerServiceStatus(t *testing.T) {
+	app := &service.Application{MainFunc: func(context.Context, <-chan struct{}) error { return nil }}
+	keeper := &service.ServiceKeeper{Services: []service.Service{okService{}}}
+	if err := keeper.Init(context.Background()); err != nil {
+		t.Fatalf("keeper.Init() = %v, want nil", err)
+	}
+
+	h := NewHandler(app, keeper)
+	resp := get(t, h, "/healthz")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("/healthz status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("/healthz Content-Type = %q, want application/json", ct)
+	}
+
+	var report healthReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		t.Fatalf("decoding /healthz body: %v", err)
+	}
+	if len(report.Services) != 1 {
+		t.Fatalf("len(report.Services) = %d, want 1", len(report.Services))
+	}
+	if report.Services[0].Service == "" {
+		t.Fatal("report.Services[0].Service is empty, want the service's type name")
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// WithTimeout decorates s so that Init, Ping and Close each run in their own goroutine and are
+// aborted if they don't complete within initTO, pingTO and closeTO respectively. This keeps a
+// hung remote (e.g. an unresponsive net/rpc peer) from stalling initAllServices or the
+// ServiceKeeper's background ping loop indefinitely. A zero timeout disables the bound for that
+// call.
+func WithTimeout(s Service, initTO, pingTO, closeTO time.Duration) Service {
+	return &timeoutService{svc: s, initTO: initTO, pingTO: pingTO, closeTO: closeTO}
+}
+
+type timeoutService struct {
+	svc     Service
+	initTO  time.Duration
+	pingTO  time.Duration
+	closeTO time.Duration
+}
+
+func (t *timeoutService) Init(ctx context.Context) error {
+	return callWithTimeout(ctx, t.initTO, t.svc.Init)
+}
+
+func (t *timeoutService) Ping(ctx context.Context) error {
+	return callWithTimeout(ctx, t.pingTO, t.svc.Ping)
+}
+
+func (t *timeoutService) Close() error {
+	return callWithTimeout(context.Background(), t.closeTO, func(context.Context) error {
+		return t.svc.Close()
+	})
+}
+
+func callWithTimeout(ctx context.Context, timeout time.Duration, call func(context.Context) error) error {
+	if timeout <= 0 {
+		return call(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- call(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %s", ErrServiceTimeout, ctx.Err())
+	}
+}
+
+// RetryPolicy configures the exponential backoff WithRetry applies between attempts.
+type RetryPolicy struct {
+	// MaxAttempts caps the number of Init calls made, including the first one. Defaults to 1
+	// (no retry) when left zero.
+	MaxAttempts int
+	// BaseDelay bounds the backoff before the second attempt; the bound doubles on every attempt
+	// after that, and the actual wait is a random value below it. Defaults to 100ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff. Defaults to 30s.
+	MaxDelay time.Duration
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	base := p.BaseDelay
+	if base <= 0 {
+		base = 100 * time.Millisecond
+	}
+	max := p.MaxDelay
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+
+	d := base * time.Duration(1<<uint(attempt-1))
+	if d <= 0 || d > max {
+		d = max
+	}
+
+	// full jitter: a random value in [0, d)
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+// WithRetry decorates s so that Init is retried, with exponential backoff and jitter, up to
+// policy.MaxAttempts times. Ping is passed through unchanged, since retrying it would defeat
+// its purpose as a health signal.
+func WithRetry(s Service, policy RetryPolicy) Service {
+	return &retryService{svc: s, policy: policy}
+}
+
+type retryService struct {
+	svc    Service
+	policy RetryPolicy
+}
+
+func (r *retryService) Init(ctx context.Context) error {
+	attempts := r.policy.MaxAttempts
+	if attempts <= 0 {
+		attempts = 1
+	}
+
+	var err error
+	for attempt := 0; attempt < attempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(r.policy.delay(attempt)):
+			}
+		}
+
+		if err = r.svc.Init(ctx); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func (r *retryService) Ping(ctx context.Context) error {
+	return r.svc.Ping(ctx)
+}
+
+func (r *retryService) Close() error {
+	return r.svc.Close()
+}
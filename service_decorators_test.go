@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type blockingService struct{}
+
+func (blockingService) Init(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+func (blockingService) Ping(ctx context.Context) error { <-ctx.Done(); return ctx.Err() }
+func (blockingService) Close() error                   { return nil }
+
+func TestWithTimeoutReturnsErrServiceTimeout(t *testing.T) {
+	svc := WithTimeout(blockingService{}, 10*time.Millisecond, 10*time.Millisecond, 10*time.Millisecond)
+
+	if err := svc.Init(context.Background()); !errors.Is(err, ErrServiceTimeout) {
+		t.Fatalf("Init() = %v, want ErrServiceTimeout", err)
+	}
+	if err := svc.Ping(context.Background()); !errors.Is(err, ErrServiceTimeout) {
+		t.Fatalf("Ping() = %v, want ErrServiceTimeout", err)
+	}
+}
+
+type flakyInitService struct {
+	failures int
+}
+
+func (f *flakyInitService) Init(context.Context) error {
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("not ready yet")
+	}
+	return nil
+}
+func (*flakyInitService) Ping(context.Context) error { return nil }
+func (*flakyInitService) Close() error               { return nil }
+
+func TestWithRetryRetriesInitUntilSuccess(t *testing.T) {
+	inner := &flakyInitService{failures: 2}
+	svc := WithRetry(inner, RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+	if err := svc.Init(context.Background()); err != nil {
+		t.Fatalf("Init() = %v, want nil after retries", err)
+	}
+}
+
+func TestWithRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	inner := &flakyInitService{failures: 10}
+	svc := WithRetry(inner, RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond})
+
+	if err := svc.Init(context.Background()); err == nil {
+		t.Fatal("Init() = nil, want an error after exhausting MaxAttempts")
+	}
+}
@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
+	"time"
 )
 
 type (
@@ -22,9 +24,32 @@ type (
 		Close() error
 	}
 
+	// Action tells the ServiceKeeper how to react when a Service's Ping returns an error.
+	Action int
+
 	ServiceKeeper struct {
 		Services []Service
+		// PingPeriod sets the interval between two consecutive Ping calls for every service.
+		PingPeriod time.Duration
+		// PingTimeout bounds a single Ping call. If it is not set, Ping runs with the parent context as is.
+		PingTimeout time.Duration
+		// UnhealthyFunc decides what to do when a service's Ping returns an error. If left nil, every
+		// failed Ping results in ActionShutdown, matching the Service.Ping doc comment.
+		UnhealthyFunc func(svc Service, err error) Action
+
 		state int32 //for control executing stages
+
+		// pingMux guards lastPing/lastErr/failures, which are keyed by the index of the
+		// service within Services rather than the Service value itself: a Service
+		// implementation is free to hold a slice, map or func field, and interface values
+		// of such types panic on comparison, which rules them out as map keys.
+		pingMux  sync.Mutex
+		lastPing []time.Time
+		lastErr  []error
+		failures []int
+
+		done chan struct{}
+		wg   sync.WaitGroup
 	}
 )
 
@@ -37,6 +62,16 @@ const (
 
 )
 
+const (
+	// ActionShutdown stops the ServiceKeeper's Watch with the Ping error, which in turn causes
+	// Application.Run to Halt. This is the default when UnhealthyFunc is nil.
+	ActionShutdown Action = iota
+	// ActionRestart calls Init again, with backoff, and resumes pinging once it succeeds.
+	ActionRestart
+	// ActionIgnore keeps pinging on schedule and otherwise does nothing.
+	ActionIgnore
+)
+
 func (s *ServiceKeeper) initAllServices(ctx context.Context) error {
 	for i := range s.Services {
 		if err := s.Services[i].Init(ctx); err != nil {
@@ -57,4 +92,192 @@ func (s *ServiceKeeper) Init(ctx context.Context) error  {
 	}
 
 	return s.initAllServices(ctx)
-}
\ No newline at end of file
+}
+
+// Watch starts one ping loop per service and blocks until Stop is called or a service's
+// UnhealthyFunc decides on ActionShutdown, in which case the triggering Ping error is returned.
+func (s *ServiceKeeper) Watch(ctx context.Context) error {
+	// done is allocated under the same lock Stop uses to read it and flip state to
+	// srvStateRunnig, so a Stop racing with the very start of Watch can never observe
+	// srvStateRunnig before done exists.
+	s.pingMux.Lock()
+	if s.state != srvStateReady {
+		s.pingMux.Unlock()
+		return ErrWrongState
+	}
+	s.done = make(chan struct{})
+	s.lastPing = make([]time.Time, len(s.Services))
+	s.lastErr = make([]error, len(s.Services))
+	s.failures = make([]int, len(s.Services))
+	atomic.StoreInt32(&s.state, srvStateRunnig)
+	s.pingMux.Unlock()
+
+	errCh := make(chan error, len(s.Services))
+
+	s.wg.Add(len(s.Services))
+	for i, svc := range s.Services {
+		i, svc := i, svc
+		go func() {
+			defer s.wg.Done()
+			s.pingLoop(ctx, i, svc, errCh)
+		}()
+	}
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *ServiceKeeper) pingLoop(ctx context.Context, i int, svc Service, errCh chan<- error) {
+	ticker := time.NewTicker(s.pingPeriod())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.done:
+			return
+		case <-ticker.C:
+		}
+
+		err := s.ping(ctx, svc)
+		s.recordPing(i, err)
+		if err == nil {
+			continue
+		}
+
+		switch s.unhealthyAction(svc, err) {
+		case ActionIgnore:
+			continue
+		case ActionRestart:
+			if !s.restart(ctx, svc) {
+				return
+			}
+		default: // ActionShutdown
+			select {
+			case errCh <- err:
+			default:
+			}
+			return
+		}
+	}
+}
+
+func (s *ServiceKeeper) ping(ctx context.Context, svc Service) error {
+	if s.PingTimeout <= 0 {
+		return svc.Ping(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, s.PingTimeout)
+	defer cancel()
+	return svc.Ping(ctx)
+}
+
+func (s *ServiceKeeper) pingPeriod() time.Duration {
+	if s.PingPeriod > 0 {
+		return s.PingPeriod
+	}
+	return time.Second
+}
+
+func (s *ServiceKeeper) unhealthyAction(svc Service, err error) Action {
+	if s.UnhealthyFunc == nil {
+		return ActionShutdown
+	}
+	return s.UnhealthyFunc(svc, err)
+}
+
+// restart retries svc.Init with exponential backoff until it succeeds, ctx is cancelled, or
+// Stop is called. It reports whether the ping loop should keep going.
+func (s *ServiceKeeper) restart(ctx context.Context, svc Service) bool {
+	backoff := 100 * time.Millisecond
+	const maxBackoff = 30 * time.Second
+
+	for {
+		if err := svc.Init(ctx); err == nil {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-s.done:
+			return false
+		case <-time.After(backoff):
+		}
+
+		if backoff *= 2; backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+func (s *ServiceKeeper) recordPing(i int, err error) {
+	s.pingMux.Lock()
+	defer s.pingMux.Unlock()
+
+	s.lastPing[i] = time.Now()
+	s.lastErr[i] = err
+	if err != nil {
+		s.failures[i]++
+	} else {
+		s.failures[i] = 0
+	}
+}
+
+// LastPing reports when the service at index i (its position in Services) was last pinged,
+// and the error that ping returned, if any. It returns the zero time before the first ping.
+func (s *ServiceKeeper) LastPing(i int) (time.Time, error) {
+	s.pingMux.Lock()
+	defer s.pingMux.Unlock()
+
+	if i < 0 || i >= len(s.lastPing) {
+		return time.Time{}, nil
+	}
+	return s.lastPing[i], s.lastErr[i]
+}
+
+// Failures reports how many consecutive Ping calls have failed for the service at index i
+// (its position in Services) since its last successful one.
+func (s *ServiceKeeper) Failures(i int) int {
+	s.pingMux.Lock()
+	defer s.pingMux.Unlock()
+
+	if i < 0 || i >= len(s.failures) {
+		return 0
+	}
+	return s.failures[i]
+}
+
+// Initialized reports whether Init has been called on this keeper. It is already true while
+// the services are still initializing, and it stays true if Init returned an error.
+func (s *ServiceKeeper) Initialized() bool {
+	return atomic.LoadInt32(&s.state) != srvStateInit
+}
+
+// Stop signals every ping loop started by Watch to terminate.
+func (s *ServiceKeeper) Stop() {
+	s.pingMux.Lock()
+	done := s.done
+	ok := atomic.CompareAndSwapInt32(&s.state, srvStateRunnig, srvStateShutdown)
+	s.pingMux.Unlock()
+
+	if ok {
+		close(done)
+	}
+}
+
+// Release waits for the ping loops to exit and closes every service.
+func (s *ServiceKeeper) Release() {
+	s.wg.Wait()
+	for i := range s.Services {
+		s.Services[i].Close()
+	}
+	s.checkState(srvStateShutdown, srvStateOff)
+}
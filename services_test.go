@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeService's underlying type holds a slice field, so a Service interface value wrapping it
+// (by value, not by pointer) panics if ever used as a map key or compared with ==.
+type fakeService struct {
+	tags    []string
+	mux     *sync.Mutex
+	pings   *int
+	initErr error
+	pingErr error
+}
+
+func (f fakeService) Init(context.Context) error { return f.initErr }
+func (f fakeService) Ping(context.Context) error {
+	f.mux.Lock()
+	*f.pings++
+	f.mux.Unlock()
+	return f.pingErr
+}
+func (f fakeService) Close() error { return nil }
+
+func newFakeService() fakeService {
+	return fakeService{tags: []string{"a", "b"}, mux: &sync.Mutex{}, pings: new(int)}
+}
+
+func TestServiceKeeperPingLoopHandlesUncomparableService(t *testing.T) {
+	svc := newFakeService()
+
+	keeper := &ServiceKeeper{
+		Services:   []Service{svc},
+		PingPeriod: 5 * time.Millisecond,
+	}
+	if err := keeper.Init(context.Background()); err != nil {
+		t.Fatalf("Init() = %v, want nil", err)
+	}
+
+	watchErr := make(chan error, 1)
+	go func() { watchErr <- keeper.Watch(context.Background()) }()
+
+	time.Sleep(30 * time.Millisecond)
+	keeper.Stop()
+
+	select {
+	case err := <-watchErr:
+		if err != nil {
+			t.Fatalf("Watch() = %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return after Stop")
+	}
+
+	lastPing, err := keeper.LastPing(0)
+	if lastPing.IsZero() {
+		t.Fatal("expected at least one ping to be recorded")
+	}
+	if err != nil {
+		t.Fatalf("LastPing() err = %v, want nil", err)
+	}
+}
+
+func TestServiceKeeperDefaultActionIsShutdownOnPingError(t *testing.T) {
+	errBoom := errors.New("boom")
+	svc := newFakeService()
+	svc.pingErr = errBoom
+
+	keeper := &ServiceKeeper{
+		Services:   []Service{svc},
+		PingPeriod: 5 * time.Millisecond,
+	}
+	if err := keeper.Init(context.Background()); err != nil {
+		t.Fatalf("Init() = %v, want nil", err)
+	}
+
+	watchErr := make(chan error, 1)
+	go func() { watchErr <- keeper.Watch(context.Background()) }()
+
+	select {
+	case err := <-watchErr:
+		if !errors.Is(err, errBoom) {
+			t.Fatalf("Watch() = %v, want %v", err, errBoom)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not return the ping error")
+	}
+
+	_, lastErr := keeper.LastPing(0)
+	if !errors.Is(lastErr, errBoom) {
+		t.Fatalf("LastPing() err = %v, want %v", lastErr, errBoom)
+	}
+	if keeper.Failures(0) == 0 {
+		t.Fatal("expected at least one recorded failure")
+	}
+}